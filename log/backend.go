@@ -0,0 +1,81 @@
+package log
+
+import (
+	"fmt"
+	"log/syslog"
+	"strings"
+)
+
+// Backend is a log sink: something that can accept an already
+// formatted, leveled message. Built-in backends cover syslog, a local
+// file, a colorized console, and a bounded in-memory ring buffer; use
+// MultiBackend to fan a single log call out to several of them at
+// once.
+type Backend interface {
+	Log(level int, msg string) error
+}
+
+// backend is the process-wide Backend emit writes to once configured
+// via SetBackend. While nil (the default), emit preserves the
+// package's historical behavior of writing through SysLogger if set,
+// or the standard library logger otherwise.
+var backend Backend
+
+// SetBackend installs b as the Backend that all package-level and
+// PackageLogger log calls are written to, replacing the legacy
+// SysLogger/stdlib fallback. Pass a MultiBackend to log to several
+// destinations at once.
+func SetBackend(b Backend) {
+	backend = b
+}
+
+// SyslogBackend writes to a *syslog.Writer, mapping Boulder's log
+// levels onto the nearest syslog severity. It wraps the same behavior
+// the package used unconditionally before Backend existed.
+type SyslogBackend struct {
+	w *syslog.Writer
+}
+
+// NewSyslogBackend returns a SyslogBackend that writes to w.
+func NewSyslogBackend(w *syslog.Writer) *SyslogBackend {
+	return &SyslogBackend{w: w}
+}
+
+// Log implements Backend.
+func (b *SyslogBackend) Log(level int, msg string) error {
+	switch level {
+	case LevelDebug:
+		return b.w.Debug(msg)
+	case LevelInfo:
+		return b.w.Info(msg)
+	case LevelWarning:
+		return b.w.Warning(msg)
+	case LevelError:
+		return b.w.Err(msg)
+	case LevelCritical:
+		return b.w.Crit(msg)
+	case LevelFatal:
+		return b.w.Emerg(msg)
+	default:
+		return fmt.Errorf("log: unknown level %d", level)
+	}
+}
+
+// MultiBackend fans a log call out to every Backend it contains,
+// collecting rather than stopping on individual failures so one
+// misbehaving backend (e.g. a full disk) doesn't silence the others.
+type MultiBackend []Backend
+
+// Log implements Backend.
+func (m MultiBackend) Log(level int, msg string) error {
+	var errs []string
+	for _, b := range m {
+		if err := b.Log(level, msg); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("log: %d of %d backends failed: %s", len(errs), len(m), strings.Join(errs, "; "))
+	}
+	return nil
+}
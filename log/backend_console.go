@@ -0,0 +1,62 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// levelColor holds the ANSI color escape used for each level when a
+// ConsoleBackend is writing to a terminal.
+var levelColor = [...]string{
+	LevelDebug:    "\x1b[37m", // white
+	LevelInfo:     "\x1b[36m", // cyan
+	LevelWarning:  "\x1b[33m", // yellow
+	LevelError:    "\x1b[31m", // red
+	LevelCritical: "\x1b[35m", // magenta
+	LevelFatal:    "\x1b[41m", // red background
+}
+
+const colorReset = "\x1b[0m"
+
+// ConsoleBackend writes to out, colorizing each line by level with
+// ANSI escape codes when isTTY is true and falling back to plain
+// monochrome text otherwise, so redirecting output to a file or a pipe
+// doesn't leave escape codes in it.
+type ConsoleBackend struct {
+	mu    sync.Mutex
+	out   io.Writer
+	isTTY bool
+}
+
+// NewConsoleBackend returns a ConsoleBackend writing to out. Pass
+// IsTerminal(out) for isTTY to colorize only when out is an
+// interactive terminal.
+func NewConsoleBackend(out io.Writer, isTTY bool) *ConsoleBackend {
+	return &ConsoleBackend{out: out, isTTY: isTTY}
+}
+
+// Log implements Backend. msg arrives already rendered by the caller's
+// Formatter (e.g. "[ERROR] message"); Log only adds color, it does not
+// format the line itself.
+func (c *ConsoleBackend) Log(level int, msg string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.isTTY {
+		_, err := fmt.Fprintf(c.out, "%s%s%s\n", levelColor[level], msg, colorReset)
+		return err
+	}
+	_, err := fmt.Fprintf(c.out, "%s\n", msg)
+	return err
+}
+
+// IsTerminal reports whether f looks like an interactive terminal
+// rather than a redirected file or pipe.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
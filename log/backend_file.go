@@ -0,0 +1,85 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileBackend writes log lines to a file on disk, rotating it once it
+// exceeds MaxBytes (if non-zero) or has been open for MaxAge (if
+// non-zero). The rotated-out file is renamed with a timestamp suffix;
+// FileBackend does not prune old rotations itself.
+type FileBackend struct {
+	mu       sync.Mutex
+	path     string
+	f        *os.File
+	size     int64
+	opened   time.Time
+	MaxBytes int64
+	MaxAge   time.Duration
+}
+
+// NewFileBackend opens (creating if necessary) path for appending and
+// returns a FileBackend writing to it. Either maxBytes or maxAge may be
+// zero to disable that rotation trigger.
+func NewFileBackend(path string, maxBytes int64, maxAge time.Duration) (*FileBackend, error) {
+	fb := &FileBackend{path: path, MaxBytes: maxBytes, MaxAge: maxAge}
+	if err := fb.open(); err != nil {
+		return nil, err
+	}
+	return fb, nil
+}
+
+func (fb *FileBackend) open() error {
+	f, err := os.OpenFile(fb.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	fb.f = f
+	fb.size = info.Size()
+	fb.opened = time.Now()
+	return nil
+}
+
+func (fb *FileBackend) rotateIfNeeded() error {
+	needsRotation := (fb.MaxBytes > 0 && fb.size >= fb.MaxBytes) ||
+		(fb.MaxAge > 0 && time.Since(fb.opened) >= fb.MaxAge)
+	if !needsRotation {
+		return nil
+	}
+	if err := fb.f.Close(); err != nil {
+		return err
+	}
+	rotated := fmt.Sprintf("%s.%s", fb.path, time.Now().Format("20060102T150405"))
+	if err := os.Rename(fb.path, rotated); err != nil {
+		return err
+	}
+	return fb.open()
+}
+
+// Log implements Backend. msg arrives already rendered by the
+// caller's Formatter (e.g. "[ERROR] message"); Log writes it as-is.
+func (fb *FileBackend) Log(level int, msg string) error {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	if err := fb.rotateIfNeeded(); err != nil {
+		return err
+	}
+	n, err := fmt.Fprintf(fb.f, "%s\n", msg)
+	fb.size += int64(n)
+	return err
+}
+
+// Close closes the underlying file.
+func (fb *FileBackend) Close() error {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	return fb.f.Close()
+}
@@ -0,0 +1,59 @@
+package log
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryEntry is one record retained by a MemoryBackend.
+type MemoryEntry struct {
+	Time    time.Time
+	Level   int
+	Message string
+}
+
+// MemoryBackend keeps the last size log entries in a bounded ring
+// buffer, so an admin HTTP endpoint can dump recent logs after a crash
+// even though nothing was ever written to durable storage.
+type MemoryBackend struct {
+	mu      sync.Mutex
+	entries []MemoryEntry
+	next    int
+	full    bool
+}
+
+// NewMemoryBackend returns a MemoryBackend retaining at most size
+// entries.
+func NewMemoryBackend(size int) *MemoryBackend {
+	if size < 1 {
+		size = 1
+	}
+	return &MemoryBackend{entries: make([]MemoryEntry, size)}
+}
+
+// Log implements Backend.
+func (m *MemoryBackend) Log(level int, msg string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[m.next] = MemoryEntry{Time: time.Now(), Level: level, Message: msg}
+	m.next = (m.next + 1) % len(m.entries)
+	if m.next == 0 {
+		m.full = true
+	}
+	return nil
+}
+
+// Entries returns the retained entries, oldest first.
+func (m *MemoryBackend) Entries() []MemoryEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.full {
+		out := make([]MemoryEntry, m.next)
+		copy(out, m.entries[:m.next])
+		return out
+	}
+	out := make([]MemoryEntry, len(m.entries))
+	n := copy(out, m.entries[m.next:])
+	copy(out[n:], m.entries[:m.next])
+	return out
+}
@@ -0,0 +1,149 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeBackend struct {
+	err     error
+	entries []string
+}
+
+func (f *fakeBackend) Log(level int, msg string) error {
+	f.entries = append(f.entries, msg)
+	return f.err
+}
+
+func TestMultiBackendFansOut(t *testing.T) {
+	a, b := &fakeBackend{}, &fakeBackend{}
+	mb := MultiBackend{a, b}
+	if err := mb.Log(LevelInfo, "hello"); err != nil {
+		t.Fatalf("MultiBackend.Log returned error: %s", err)
+	}
+	if len(a.entries) != 1 || len(b.entries) != 1 {
+		t.Errorf("MultiBackend did not fan out to every backend: %v %v", a.entries, b.entries)
+	}
+}
+
+func TestMultiBackendCollectsErrors(t *testing.T) {
+	a := &fakeBackend{err: errors.New("disk full")}
+	b := &fakeBackend{}
+	mb := MultiBackend{a, b}
+	err := mb.Log(LevelInfo, "hello")
+	if err == nil {
+		t.Fatal("expected an error when one backend fails")
+	}
+	if len(b.entries) != 1 {
+		t.Errorf("a failing backend should not stop others from receiving the message")
+	}
+}
+
+func TestConsoleBackendMonochromeWhenNotTTY(t *testing.T) {
+	var buf bytes.Buffer
+	c := NewConsoleBackend(&buf, false)
+	if err := c.Log(LevelWarning, "[WARNING] disk low"); err != nil {
+		t.Fatalf("Log returned error: %s", err)
+	}
+	got := buf.String()
+	if strings.Contains(got, "\x1b[") {
+		t.Errorf("monochrome ConsoleBackend emitted ANSI escapes: %q", got)
+	}
+	// Log must write the already-formatted message as-is, not
+	// reapply its own "[LEVEL] " prefix on top of the Formatter's.
+	if got != "[WARNING] disk low\n" {
+		t.Errorf("ConsoleBackend.Log output = %q", got)
+	}
+}
+
+func TestConsoleBackendColorizesWhenTTY(t *testing.T) {
+	var buf bytes.Buffer
+	c := NewConsoleBackend(&buf, true)
+	if err := c.Log(LevelError, "[ERROR] boom"); err != nil {
+		t.Fatalf("Log returned error: %s", err)
+	}
+	got := buf.String()
+	if strings.Count(got, "[ERROR]") != 1 {
+		t.Errorf("ConsoleBackend.Log should not add its own level prefix: %q", got)
+	}
+	if !strings.Contains(got, "\x1b[") {
+		t.Errorf("TTY ConsoleBackend did not colorize output: %q", buf.String())
+	}
+}
+
+func TestSetBackendDoesNotDoublePrefixThroughPackageLevelCalls(t *testing.T) {
+	var buf bytes.Buffer
+	SetBackend(NewConsoleBackend(&buf, false))
+	defer SetBackend(nil)
+
+	Infof("hello %s", "world")
+
+	got := buf.String()
+	if strings.Count(got, "[INFO]") != 1 {
+		t.Errorf("log.Infof through a ConsoleBackend should be formatted exactly once, got %q", got)
+	}
+}
+
+func TestMemoryBackendRingBuffer(t *testing.T) {
+	mb := NewMemoryBackend(2)
+	mb.Log(LevelInfo, "one")
+	mb.Log(LevelInfo, "two")
+	mb.Log(LevelInfo, "three")
+
+	entries := mb.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Entries() returned %d entries, want 2", len(entries))
+	}
+	if entries[0].Message != "two" || entries[1].Message != "three" {
+		t.Errorf("Entries() = %v, want [two three]", entries)
+	}
+}
+
+func TestFileBackendWritesAndRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "boulder.log")
+	fb, err := NewFileBackend(path, 10, 0)
+	if err != nil {
+		t.Fatalf("NewFileBackend returned error: %s", err)
+	}
+	defer fb.Close()
+
+	if err := fb.Log(LevelInfo, "this line alone exceeds ten bytes"); err != nil {
+		t.Fatalf("Log returned error: %s", err)
+	}
+	// The next write should trigger rotation since size already
+	// exceeds MaxBytes.
+	if err := fb.Log(LevelInfo, "second"); err != nil {
+		t.Fatalf("Log returned error: %s", err)
+	}
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("Glob returned error: %s", err)
+	}
+	if len(matches) != 1 {
+		t.Errorf("expected exactly one rotated file, got %v", matches)
+	}
+}
+
+func TestFileBackendRotatesByAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "boulder.log")
+	fb, err := NewFileBackend(path, 0, time.Nanosecond)
+	if err != nil {
+		t.Fatalf("NewFileBackend returned error: %s", err)
+	}
+	defer fb.Close()
+	time.Sleep(time.Millisecond)
+	if err := fb.Log(LevelInfo, "rotate me"); err != nil {
+		t.Fatalf("Log returned error: %s", err)
+	}
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("Glob returned error: %s", err)
+	}
+	if len(matches) != 1 {
+		t.Errorf("expected exactly one rotated file, got %v", matches)
+	}
+}
@@ -0,0 +1,70 @@
+package log
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// maxStackSize bounds how much of a goroutine's stack Critical and
+// Fatal capture, so one runaway call chain can't blow up a log line.
+const maxStackSize = 4096
+
+// itoa is a cheap decimal integer-to-string conversion, avoiding the
+// allocations fmt.Sprintf would add when callerInfo formats a line
+// number.
+// It mirrors the standard library's internal log.itoa: fill a fixed
+// buffer from the back, then slice off the part that was used. wid
+// zero-pads the result to at least wid digits; pass 0 for no padding.
+// i must be non-negative, which always holds for the line numbers this
+// is used for.
+func itoa(i, wid int) string {
+	var b [20]byte
+	bp := len(b) - 1
+	for i >= 10 || wid > 1 {
+		wid--
+		q := i / 10
+		b[bp] = byte('0' + i - q*10)
+		bp--
+		i = q
+	}
+	b[bp] = byte('0' + i)
+	return string(b[bp:])
+}
+
+// callerInfo returns "file:line" for the frame skip stack frames above
+// callerInfo itself (1 is its own caller), or "" if it cannot be
+// determined.
+func callerInfo(skip int) string {
+	_, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return ""
+	}
+	return file + ":" + itoa(line, 0)
+}
+
+// truncatedStack captures the current goroutine's stack, truncated to
+// maxStackSize bytes.
+func truncatedStack() string {
+	stack := debug.Stack()
+	if len(stack) > maxStackSize {
+		stack = stack[:maxStackSize]
+	}
+	return string(stack)
+}
+
+// withCallerAndStack appends the calling package-level log call's
+// file:line to msg for LevelError and above, and a truncated stack for
+// LevelCritical and LevelFatal, so an operator can find the offending
+// call site straight from the log line.
+func withCallerAndStack(level, skip int, msg string) string {
+	if level < LevelError {
+		return msg
+	}
+	if info := callerInfo(skip); info != "" {
+		msg = msg + " (" + info + ")"
+	}
+	if level >= LevelCritical {
+		msg = msg + "\n" + truncatedStack()
+	}
+	return msg
+}
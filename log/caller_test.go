@@ -0,0 +1,117 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestItoa(t *testing.T) {
+	cases := []struct {
+		i, wid int
+		want   string
+	}{
+		{0, 0, "0"},
+		{7, 0, "7"},
+		{42, 0, "42"},
+		{1234, 0, "1234"},
+		{7, 3, "007"},
+		{42, 4, "0042"},
+	}
+	for _, c := range cases {
+		if got := itoa(c.i, c.wid); got != c.want {
+			t.Errorf("itoa(%d, %d) = %q, want %q", c.i, c.wid, got, c.want)
+		}
+	}
+}
+
+func TestWithCallerAndStackBelowError(t *testing.T) {
+	got := withCallerAndStack(LevelInfo, 2, "hello")
+	if got != "hello" {
+		t.Errorf("withCallerAndStack at LevelInfo modified the message: %q", got)
+	}
+}
+
+func TestWithCallerAndStackAppendsCallerAtError(t *testing.T) {
+	got := withCallerAndStack(LevelError, 2, "boom")
+	if !strings.Contains(got, "caller_test.go:") {
+		t.Errorf("withCallerAndStack at LevelError = %q, want it to contain the caller's file:line", got)
+	}
+	if strings.Contains(got, "goroutine") {
+		t.Errorf("withCallerAndStack at LevelError should not include a stack trace: %q", got)
+	}
+}
+
+func TestWithCallerAndStackAppendsStackAtCritical(t *testing.T) {
+	got := withCallerAndStack(LevelCritical, 2, "boom")
+	if !strings.Contains(got, "goroutine") {
+		t.Errorf("withCallerAndStack at LevelCritical = %q, want it to include a stack trace", got)
+	}
+}
+
+func TestPrintAppendsCallerForErrorAndAbove(t *testing.T) {
+	mb := NewMemoryBackend(4)
+	SetBackend(mb)
+	defer SetBackend(nil)
+
+	Info("plain info message")
+	Errorf("broken: %s", "pipe")
+
+	entries := mb.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if strings.Contains(entries[0].Message, "log.go") || strings.Contains(entries[0].Message, ".go:") {
+		t.Errorf("Info message should not have caller info appended: %q", entries[0].Message)
+	}
+	if !strings.Contains(entries[1].Message, "caller_test.go:") {
+		t.Errorf("Errorf message should have caller info appended: %q", entries[1].Message)
+	}
+}
+
+func TestPackageLevelCriticalCapturesStackExactlyOnce(t *testing.T) {
+	mb := NewMemoryBackend(1)
+	SetBackend(mb)
+	defer SetBackend(nil)
+
+	Critical("everything is on fire")
+
+	entries := mb.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	msg := entries[0].Message
+	// debug.Stack() emits "goroutine " twice when the current goroutine
+	// was spawned by a "go" statement (its header plus a "created by"
+	// trailer naming the spawning goroutine) — true of every goroutine
+	// testing.tRunner starts, so count stack headers rather than raw
+	// occurrences of "goroutine ".
+	headerRe := regexp.MustCompile(`goroutine \d+ \[running\]:`)
+	if n := len(headerRe.FindAllString(msg, -1)); n != 1 {
+		t.Errorf("Critical() should capture exactly one stack trace, found %d: %q", n, msg)
+	}
+	callerIdx := strings.Index(msg, "caller_test.go:")
+	stackIdx := strings.Index(msg, "goroutine ")
+	if callerIdx == -1 || stackIdx == -1 || callerIdx > stackIdx {
+		t.Errorf("Critical() should place the caller file:line before the stack trace: %q", msg)
+	}
+}
+
+func TestLoggerWithStackAttachesStack(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(&buf, LogfmtFormatter{}, nil).WithStack()
+	l.Info("hi", nil)
+	if !strings.Contains(buf.String(), "goroutine") {
+		t.Errorf("Logger.WithStack() should attach a stack even at LevelInfo: %q", buf.String())
+	}
+}
+
+func TestLoggerAttachesStackAtCriticalByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(&buf, LogfmtFormatter{}, nil)
+	l.Critical("critical failure", nil)
+	if !strings.Contains(buf.String(), "goroutine") {
+		t.Errorf("Logger.Critical() should attach a stack without WithStack: %q", buf.String())
+	}
+}
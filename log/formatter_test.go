@@ -0,0 +1,47 @@
+package log
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTextFormatterNoAttrs(t *testing.T) {
+	e := &Entry{Level: LevelError, Message: "boom"}
+	got := string(TextFormatter{}.Format(e))
+	if got != "[ERROR] boom" {
+		t.Errorf("TextFormatter.Format = %q, want %q", got, "[ERROR] boom")
+	}
+}
+
+func TestTextFormatterWithAttrs(t *testing.T) {
+	e := &Entry{Time: time.Unix(0, 0), Level: LevelInfo, Message: "hello", Attrs: map[string]string{"b": "2", "a": "1"}}
+	got := string(TextFormatter{}.Format(e))
+	want := "[INFO] hello a=1 b=2"
+	if got != want {
+		t.Errorf("TextFormatter.Format = %q, want %q", got, want)
+	}
+}
+
+func TestLogfmtFormatter(t *testing.T) {
+	e := &Entry{Level: LevelWarning, Message: "disk low", Attrs: map[string]string{"path": "/data"}}
+	got := string(LogfmtFormatter{}.Format(e))
+	want := `level=WARNING msg="disk low" path="/data"`
+	if got != want {
+		t.Errorf("LogfmtFormatter.Format = %q, want %q", got, want)
+	}
+}
+
+func TestJSONFormatter(t *testing.T) {
+	e := &Entry{
+		Level:   LevelError,
+		Message: "failed to open file",
+		Attrs:   map[string]string{"path": "data.bin"},
+	}
+	got := string(JSONFormatter{}.Format(e))
+	for _, want := range []string{`"level":"ERROR"`, `"msg":"failed to open file"`, `"path":"data.bin"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("JSONFormatter.Format = %q, want substring %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Package log implements Boulder's logging conventions on top of the Go
+// standard library. Clients should set the current log level; only
+// messages at or above that level will actually be logged. For example,
+// if Level is set to LevelWarning, only log messages at the Warning,
+// Error, Critical, and Fatal levels will be logged.
+//
+// The package-level functions (Debug, Infof, Errorf, ...) are
+// convenience wrappers around a default Logger and remain the easiest
+// way to log an unstructured message. Callers that want attribute-based
+// logging, a different output format, or a different destination
+// should construct their own *Logger with NewLogger.
+package log
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"log/syslog"
+	"os"
+)
+
+// The following constants represent logging levels in increasing levels of seriousness.
+const (
+	// LevelDebug is the log level for Debug statements.
+	LevelDebug = iota
+	// LevelInfo is the log level for Info statements.
+	LevelInfo
+	// LevelWarning is the log level for Warning statements.
+	LevelWarning
+	// LevelError is the log level for Error statements.
+	LevelError
+	// LevelCritical is the log level for Critical statements.
+	LevelCritical
+	// LevelFatal is the log level for Fatal statements.
+	LevelFatal
+)
+
+var levelPrefix = [...]string{
+	LevelDebug:    "DEBUG",
+	LevelInfo:     "INFO",
+	LevelWarning:  "WARNING",
+	LevelError:    "ERROR",
+	LevelCritical: "CRITICAL",
+	LevelFatal:    "FATAL",
+}
+
+var (
+	// Level stores the current logging level.
+	Level = LevelInfo
+	// SysLogger is a syslog Writer to be used if not nil.
+	SysLogger *syslog.Writer
+)
+
+func init() {
+	flag.IntVar(&Level, "loglevel", LevelInfo, "Log level (0 = DEBUG, 5 = FATAL)")
+}
+
+// print consults the calling package's PackageLogger, if one has been
+// registered and given an explicit level, and otherwise falls back to
+// the global Level, before handing the message to emit. For
+// LevelError and above it also appends the original caller's
+// file:line (and, for LevelCritical and above, a stack trace) so the
+// call site survives whatever aggregation happens downstream.
+func print(l int, msg string) {
+	if l < packageOrGlobalLevel(callerSkip) {
+		return
+	}
+	emit(l, withCallerAndStack(l, callerSkip, msg))
+}
+
+// emit dispatches an already-formatted message to the Backend
+// installed with SetBackend, or, if none has been installed, to
+// syslog if SysLogger is configured, or to the standard library
+// logger otherwise. It is the single place that decides *where* a log
+// line ends up, with no level filtering of its own; callers are
+// expected to have already decided the message should be logged.
+func emit(l int, msg string) {
+	if backend != nil {
+		if err := backend.Log(l, msg); err != nil {
+			log.Printf("log: backend error: %v for msg: %s\n", err, msg)
+		}
+		return
+	}
+	if SysLogger != nil {
+		var err error
+		switch l {
+		case LevelDebug:
+			err = SysLogger.Debug(msg)
+		case LevelInfo:
+			err = SysLogger.Info(msg)
+		case LevelWarning:
+			err = SysLogger.Warning(msg)
+		case LevelError:
+			err = SysLogger.Err(msg)
+		case LevelCritical:
+			err = SysLogger.Crit(msg)
+		case LevelFatal:
+			err = SysLogger.Emerg(msg)
+		}
+		if err != nil {
+			log.Printf("Unable to write syslog: %v for msg: %s\n", err, msg)
+		}
+		return
+	}
+	log.Print(msg)
+}
+
+// defaultLogger backs the package-level Debug/Info/.../Fatal functions
+// below. It uses the text formatter so existing log output is
+// unchanged, and its entries are routed through print so SysLogger
+// configuration continues to apply to every package-level call.
+var defaultLogger = NewLogger(os.Stderr, TextFormatter{}, nil)
+
+func outputf(l int, format string, v []interface{}) {
+	defaultLogger.log(l, fmt.Sprintf(format, redactArgs(v)...), nil)
+}
+
+func output(l int, v []interface{}) {
+	defaultLogger.log(l, fmt.Sprint(redactArgs(v)...), nil)
+}
+
+// Fatalf logs a formatted message at the "fatal" level and then exits. The
+// arguments are handled in the same manner as fmt.Printf.
+func Fatalf(format string, v ...interface{}) {
+	outputf(LevelFatal, format, v)
+	os.Exit(1)
+}
+
+// Fatal logs its arguments at the "fatal" level and then exits.
+func Fatal(v ...interface{}) {
+	output(LevelFatal, v)
+	os.Exit(1)
+}
+
+// Criticalf logs a formatted message at the "critical" level. The
+// arguments are handled in the same manner as fmt.Printf.
+func Criticalf(format string, v ...interface{}) {
+	outputf(LevelCritical, format, v)
+}
+
+// Critical logs its arguments at the "critical" level.
+func Critical(v ...interface{}) {
+	output(LevelCritical, v)
+}
+
+// Errorf logs a formatted message at the "error" level. The arguments
+// are handled in the same manner as fmt.Printf.
+func Errorf(format string, v ...interface{}) {
+	outputf(LevelError, format, v)
+}
+
+// Error logs its arguments at the "error" level.
+func Error(v ...interface{}) {
+	output(LevelError, v)
+}
+
+// Warningf logs a formatted message at the "warning" level. The
+// arguments are handled in the same manner as fmt.Printf.
+func Warningf(format string, v ...interface{}) {
+	outputf(LevelWarning, format, v)
+}
+
+// Warning logs its arguments at the "warning" level.
+func Warning(v ...interface{}) {
+	output(LevelWarning, v)
+}
+
+// Infof logs a formatted message at the "info" level. The arguments
+// are handled in the same manner as fmt.Printf.
+func Infof(format string, v ...interface{}) {
+	outputf(LevelInfo, format, v)
+}
+
+// Info logs its arguments at the "info" level.
+func Info(v ...interface{}) {
+	output(LevelInfo, v)
+}
+
+// Debugf logs a formatted message at the "debug" level. The arguments
+// are handled in the same manner as fmt.Printf.
+func Debugf(format string, v ...interface{}) {
+	outputf(LevelDebug, format, v)
+}
+
+// Debug logs its arguments at the "debug" level.
+func Debug(v ...interface{}) {
+	output(LevelDebug, v)
+}
@@ -0,0 +1,142 @@
+package log
+
+import (
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Entry is a single structured log record, handed to a Formatter
+// before being written out by a Logger. Stack is populated for
+// LevelCritical and above, or for any level on a Logger returned by
+// WithStack. The default Logger behind the package-level functions
+// leaves Stack empty; print appends the stack to the message instead.
+type Entry struct {
+	Time    time.Time
+	Level   int
+	Message string
+	Attrs   map[string]string
+	Stack   string
+}
+
+// Logger is an attribute-aware logger: it renders each log call as an
+// Entry, formats it with a Formatter, and writes the result to an
+// io.Writer. Unlike the package-level Debug/Info/.../Fatal functions,
+// a Logger can be configured with its own formatter, destination, and
+// default attributes (e.g. actor, subsystem, request ID) that are
+// merged into every Entry it emits.
+type Logger struct {
+	mu           sync.Mutex
+	out          io.Writer
+	formatter    Formatter
+	defaultAttrs map[string]string
+	forceStack   bool
+}
+
+// NewLogger constructs a Logger that formats entries with f and writes
+// them to out. defaultAttrs, if non-nil, are merged into every Entry
+// logged through the returned Logger; attributes passed to a specific
+// call win over a default of the same name.
+func NewLogger(out io.Writer, f Formatter, defaultAttrs map[string]string) *Logger {
+	return &Logger{
+		out:          out,
+		formatter:    f,
+		defaultAttrs: defaultAttrs,
+	}
+}
+
+func (l *Logger) mergeAttrs(attrs map[string]string) map[string]string {
+	if len(l.defaultAttrs) == 0 {
+		return attrs
+	}
+	merged := make(map[string]string, len(l.defaultAttrs)+len(attrs))
+	for k, v := range l.defaultAttrs {
+		merged[k] = v
+	}
+	for k, v := range attrs {
+		merged[k] = v
+	}
+	return merged
+}
+
+// WithStack returns a copy of l that always attaches a captured
+// goroutine stack to every Entry it logs, regardless of level. Use it
+// to instrument a specific code path rather than leaving stack capture
+// as the default for every Info/Debug call on l.
+func (l *Logger) WithStack() *Logger {
+	return &Logger{
+		out:          l.out,
+		formatter:    l.formatter,
+		defaultAttrs: l.defaultAttrs,
+		forceStack:   true,
+	}
+}
+
+// log builds an Entry and hands it to the Logger's formatter and
+// writer. The default Logger is special-cased to go through print
+// instead, so that SysLogger configuration keeps applying uniformly
+// to the package-level Debug/Info/.../Fatal functions; print does its
+// own level check against the global Level (and any per-package
+// override), so a plain Logger constructed with NewLogger is gated
+// here against the global Level directly.
+func (l *Logger) log(level int, msg string, attrs map[string]string) {
+	if l != defaultLogger && level < Level {
+		return
+	}
+	e := &Entry{
+		Time:    time.Now(),
+		Level:   level,
+		Message: msg,
+		Attrs:   l.mergeAttrs(attrs),
+	}
+	// The default Logger's entries go through print below, which
+	// already appends a caller file:line and (for LevelCritical and
+	// above) its own stack trace via withCallerAndStack. Populating
+	// e.Stack here too would attach a second, independently captured
+	// stack ahead of that caller annotation instead of after it.
+	if l != defaultLogger && (level >= LevelCritical || l.forceStack) {
+		e.Stack = truncatedStack()
+	}
+	formatted := l.formatter.Format(e)
+	if l == defaultLogger {
+		print(level, string(formatted))
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.out.Write(formatted)
+	l.out.Write([]byte("\n"))
+}
+
+// Debug logs msg and attrs at the "debug" level.
+func (l *Logger) Debug(msg string, attrs map[string]string) {
+	l.log(LevelDebug, msg, attrs)
+}
+
+// Info logs msg and attrs at the "info" level.
+func (l *Logger) Info(msg string, attrs map[string]string) {
+	l.log(LevelInfo, msg, attrs)
+}
+
+// Warning logs msg and attrs at the "warning" level.
+func (l *Logger) Warning(msg string, attrs map[string]string) {
+	l.log(LevelWarning, msg, attrs)
+}
+
+// Error logs msg and attrs at the "error" level.
+func (l *Logger) Error(msg string, attrs map[string]string) {
+	l.log(LevelError, msg, attrs)
+}
+
+// Critical logs msg and attrs at the "critical" level.
+func (l *Logger) Critical(msg string, attrs map[string]string) {
+	l.log(LevelCritical, msg, attrs)
+}
+
+// Fatal logs msg and attrs at the "fatal" level and then exits,
+// mirroring the package-level Fatal function.
+func (l *Logger) Fatal(msg string, attrs map[string]string) {
+	l.log(LevelFatal, msg, attrs)
+	os.Exit(1)
+}
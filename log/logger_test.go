@@ -0,0 +1,53 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLoggerWritesFormattedEntry(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(&buf, LogfmtFormatter{}, map[string]string{"subsystem": "ra"})
+	l.Error("failed to open file", map[string]string{"path": "data.bin"})
+
+	got := buf.String()
+	for _, want := range []string{"level=ERROR", `msg="failed to open file"`, `subsystem="ra"`, `path="data.bin"`} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("Logger output = %q, want substring %q", got, want)
+		}
+	}
+}
+
+func TestLoggerAttrOverridesDefault(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(&buf, LogfmtFormatter{}, map[string]string{"subsystem": "ra"})
+	l.Info("hi", map[string]string{"subsystem": "ca"})
+	if !bytes.Contains(buf.Bytes(), []byte(`subsystem="ca"`)) {
+		t.Errorf("Logger output = %q, want call-site attr to win over default", buf.String())
+	}
+}
+
+func TestLoggerRespectsGlobalLevel(t *testing.T) {
+	savedLevel := Level
+	Level = LevelWarning
+	defer func() { Level = savedLevel }()
+
+	var buf bytes.Buffer
+	l := NewLogger(&buf, LogfmtFormatter{}, nil)
+	l.Info("should be filtered out", nil)
+	if buf.Len() != 0 {
+		t.Errorf("Logger.Info logged below the global Level: %q", buf.String())
+	}
+
+	l.Error("should still log", nil)
+	if buf.Len() == 0 {
+		t.Errorf("Logger.Error should log at or above the global Level")
+	}
+}
+
+func TestDefaultLoggerRoutesThroughPrint(t *testing.T) {
+	// Infof/Errorf must keep working unchanged for existing callers
+	// that never touch the structured API.
+	Infof("legacy %s still works", "callers")
+	Errorf("legacy %s still works", "callers")
+}
@@ -0,0 +1,253 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// inheritLevel marks a PackageLogger as having no explicit level of
+// its own, so it falls back to the global Level.
+const inheritLevel = -1
+
+// PackageLogger lets a single Boulder subsystem (RA, CA, VA, SA, WFE,
+// ...) have its log verbosity controlled independently of the global
+// Level, similar to capnslog's per-package repository of loggers.
+// Construct one with NewPackageLogger and use its Debug/Info/.../Fatal
+// methods in place of the package-level functions of the same name.
+type PackageLogger struct {
+	name  string
+	level int
+}
+
+// RepoLogger is a registry of PackageLogger values keyed by package
+// name (e.g. "github.com/letsencrypt/boulder/ra"), used to look up and
+// adjust per-package log levels at runtime.
+type RepoLogger struct {
+	mu   sync.RWMutex
+	pkgs map[string]*PackageLogger
+}
+
+var repoLogger = &RepoLogger{pkgs: make(map[string]*PackageLogger)}
+
+// callerSkip is how many stack frames print ascends, via
+// packageOrGlobalLevel, to find the package of the code that actually
+// made a package-level log call. It exists because that call passes
+// through outputf/output and Logger.log before reaching print.
+// SetCallerSkip lets a wrapper function correct this when it adds
+// frames of its own.
+var callerSkip = 6
+
+// packageLoggerCallerSkip is callerSkip's counterpart for
+// PackageLogger.output, which calls withCallerAndStack directly rather
+// than going through outputf/output and Logger.log first, so it needs
+// fewer frames ascended to land on the same external caller.
+const packageLoggerCallerSkip = 4
+
+// NewPackageLogger returns the PackageLogger for repo/pkg, creating it
+// with no explicit level (i.e. inheriting the global Level) if this is
+// the first call for that package. repo/pkg should match the Go import
+// path of the calling package, since that's what print looks up when
+// deciding whether a package-level log call should be filtered.
+func NewPackageLogger(repo, pkg string) *PackageLogger {
+	name := repo + "/" + pkg
+	repoLogger.mu.Lock()
+	defer repoLogger.mu.Unlock()
+	if pl, ok := repoLogger.pkgs[name]; ok {
+		return pl
+	}
+	pl := &PackageLogger{name: name, level: inheritLevel}
+	repoLogger.pkgs[name] = pl
+	return pl
+}
+
+// SetLevel sets this package's log level explicitly, overriding the
+// global Level until ResetLevel is called.
+func (pl *PackageLogger) SetLevel(level int) {
+	repoLogger.mu.Lock()
+	defer repoLogger.mu.Unlock()
+	pl.level = level
+}
+
+// ResetLevel makes this package fall back to the global Level again.
+func (pl *PackageLogger) ResetLevel() {
+	repoLogger.mu.Lock()
+	defer repoLogger.mu.Unlock()
+	pl.level = inheritLevel
+}
+
+func (pl *PackageLogger) effectiveLevel() int {
+	repoLogger.mu.RLock()
+	defer repoLogger.mu.RUnlock()
+	if pl.level == inheritLevel {
+		return Level
+	}
+	return pl.level
+}
+
+func (pl *PackageLogger) output(level int, msg string) {
+	if level < pl.effectiveLevel() {
+		return
+	}
+	formatted := defaultLogger.formatter.Format(&Entry{Time: time.Now(), Level: level, Message: msg})
+	emit(level, withCallerAndStack(level, packageLoggerCallerSkip, string(formatted)))
+}
+
+// Debugf logs a formatted message at the "debug" level, subject to
+// this package's own level rather than the global Level.
+func (pl *PackageLogger) Debugf(format string, v ...interface{}) {
+	pl.output(LevelDebug, fmt.Sprintf(format, redactArgs(v)...))
+}
+
+// Debug logs its arguments at the "debug" level.
+func (pl *PackageLogger) Debug(v ...interface{}) {
+	pl.output(LevelDebug, fmt.Sprint(redactArgs(v)...))
+}
+
+// Infof logs a formatted message at the "info" level.
+func (pl *PackageLogger) Infof(format string, v ...interface{}) {
+	pl.output(LevelInfo, fmt.Sprintf(format, redactArgs(v)...))
+}
+
+// Info logs its arguments at the "info" level.
+func (pl *PackageLogger) Info(v ...interface{}) {
+	pl.output(LevelInfo, fmt.Sprint(redactArgs(v)...))
+}
+
+// Warningf logs a formatted message at the "warning" level.
+func (pl *PackageLogger) Warningf(format string, v ...interface{}) {
+	pl.output(LevelWarning, fmt.Sprintf(format, redactArgs(v)...))
+}
+
+// Warning logs its arguments at the "warning" level.
+func (pl *PackageLogger) Warning(v ...interface{}) {
+	pl.output(LevelWarning, fmt.Sprint(redactArgs(v)...))
+}
+
+// Errorf logs a formatted message at the "error" level.
+func (pl *PackageLogger) Errorf(format string, v ...interface{}) {
+	pl.output(LevelError, fmt.Sprintf(format, redactArgs(v)...))
+}
+
+// Error logs its arguments at the "error" level.
+func (pl *PackageLogger) Error(v ...interface{}) {
+	pl.output(LevelError, fmt.Sprint(redactArgs(v)...))
+}
+
+// Criticalf logs a formatted message at the "critical" level.
+func (pl *PackageLogger) Criticalf(format string, v ...interface{}) {
+	pl.output(LevelCritical, fmt.Sprintf(format, redactArgs(v)...))
+}
+
+// Critical logs its arguments at the "critical" level.
+func (pl *PackageLogger) Critical(v ...interface{}) {
+	pl.output(LevelCritical, fmt.Sprint(redactArgs(v)...))
+}
+
+// Fatalf logs a formatted message at the "fatal" level and then exits.
+func (pl *PackageLogger) Fatalf(format string, v ...interface{}) {
+	pl.output(LevelFatal, fmt.Sprintf(format, redactArgs(v)...))
+	os.Exit(1)
+}
+
+// Fatal logs its arguments at the "fatal" level and then exits.
+func (pl *PackageLogger) Fatal(v ...interface{}) {
+	pl.output(LevelFatal, fmt.Sprint(redactArgs(v)...))
+	os.Exit(1)
+}
+
+// SetLogLevel sets the log level for each named package in levels,
+// registering packages that have not yet called NewPackageLogger. It
+// is meant to be called once at startup from a parsed flag or config
+// value; see ParseLogLevelConfig.
+func SetLogLevel(levels map[string]int) {
+	repoLogger.mu.Lock()
+	defer repoLogger.mu.Unlock()
+	for name, level := range levels {
+		if pl, ok := repoLogger.pkgs[name]; ok {
+			pl.level = level
+			continue
+		}
+		repoLogger.pkgs[name] = &PackageLogger{name: name, level: level}
+	}
+}
+
+// SetCallerSkip overrides the number of stack frames print ascends to
+// find the package of a package-level log call. Wrapper functions that
+// add their own frame between the caller and log.Infof/log.Errorf/...
+// should call this with a count that accounts for their own depth.
+func SetCallerSkip(n int) {
+	callerSkip = n
+}
+
+// ParseLogLevelConfig parses a "pkg=LEVEL,pkg2=LEVEL" string, as might
+// come from a flag or config file, into a map suitable for SetLogLevel.
+// An empty string yields an empty, non-nil map.
+func ParseLogLevelConfig(s string) (map[string]int, error) {
+	levels := make(map[string]int)
+	if s == "" {
+		return levels, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid log level entry %q, want pkg=LEVEL", pair)
+		}
+		pkg := strings.TrimSpace(kv[0])
+		level, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level %q for package %q: %s", kv[1], pkg, err)
+		}
+		levels[pkg] = level
+	}
+	return levels, nil
+}
+
+// packageOrGlobalLevel returns the log level that should gate a
+// package-level log call, ascending skip stack frames from
+// callerPackage (1 is packageOrGlobalLevel itself, 2 its caller) to
+// find the package that actually made the call.
+func packageOrGlobalLevel(skip int) int {
+	pkg := callerPackage(skip)
+	if pkg == "" {
+		return Level
+	}
+	repoLogger.mu.RLock()
+	defer repoLogger.mu.RUnlock()
+	if pl, ok := repoLogger.pkgs[pkg]; ok && pl.level != inheritLevel {
+		return pl.level
+	}
+	return Level
+}
+
+// callerPackage returns the import path of the package that made the
+// call skip frames up the stack, or "" if it cannot be determined.
+func callerPackage(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return ""
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+	return pkgFromFuncName(fn.Name())
+}
+
+// pkgFromFuncName strips the function/method name off the end of a
+// fully qualified function name such as
+// "github.com/letsencrypt/boulder/ra.(*RegistrationAuthorityImpl).NewCertificate",
+// returning "github.com/letsencrypt/boulder/ra".
+func pkgFromFuncName(name string) string {
+	idx := strings.LastIndex(name, "/")
+	rest := name[idx+1:]
+	dot := strings.Index(rest, ".")
+	if dot < 0 {
+		return name
+	}
+	return name[:idx+1+dot]
+}
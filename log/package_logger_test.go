@@ -0,0 +1,104 @@
+package log
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewPackageLoggerReturnsSameInstance(t *testing.T) {
+	a := NewPackageLogger("github.com/letsencrypt/boulder", "ra-test")
+	b := NewPackageLogger("github.com/letsencrypt/boulder", "ra-test")
+	if a != b {
+		t.Errorf("NewPackageLogger returned different instances for the same package")
+	}
+}
+
+func TestPackageLoggerInheritsGlobalLevelByDefault(t *testing.T) {
+	pl := NewPackageLogger("github.com/letsencrypt/boulder", "inherit-test")
+	Level = LevelWarning
+	defer func() { Level = LevelInfo }()
+	if got := pl.effectiveLevel(); got != LevelWarning {
+		t.Errorf("effectiveLevel() = %d, want %d (inherited)", got, LevelWarning)
+	}
+}
+
+func TestPackageLoggerSetAndResetLevel(t *testing.T) {
+	pl := NewPackageLogger("github.com/letsencrypt/boulder", "setlevel-test")
+	pl.SetLevel(LevelDebug)
+	if got := pl.effectiveLevel(); got != LevelDebug {
+		t.Errorf("effectiveLevel() after SetLevel = %d, want %d", got, LevelDebug)
+	}
+	pl.ResetLevel()
+	if got := pl.effectiveLevel(); got != Level {
+		t.Errorf("effectiveLevel() after ResetLevel = %d, want global Level %d", got, Level)
+	}
+}
+
+func TestSetLogLevel(t *testing.T) {
+	SetLogLevel(map[string]int{"github.com/letsencrypt/boulder/sa": LevelCritical})
+	pl := NewPackageLogger("github.com/letsencrypt/boulder", "sa")
+	if got := pl.effectiveLevel(); got != LevelCritical {
+		t.Errorf("effectiveLevel() = %d, want %d after SetLogLevel", got, LevelCritical)
+	}
+}
+
+func TestParseLogLevelConfig(t *testing.T) {
+	got, err := ParseLogLevelConfig("ra=0,ca=4")
+	if err != nil {
+		t.Fatalf("ParseLogLevelConfig returned error: %s", err)
+	}
+	want := map[string]int{"ra": 0, "ca": 4}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseLogLevelConfig = %v, want %v", got, want)
+	}
+}
+
+func TestParseLogLevelConfigEmpty(t *testing.T) {
+	got, err := ParseLogLevelConfig("")
+	if err != nil {
+		t.Fatalf("ParseLogLevelConfig returned error: %s", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("ParseLogLevelConfig(\"\") = %v, want empty map", got)
+	}
+}
+
+func TestParseLogLevelConfigInvalid(t *testing.T) {
+	for _, s := range []string{"ra", "ra=notanumber", "ra=1,bad"} {
+		if _, err := ParseLogLevelConfig(s); err == nil {
+			t.Errorf("ParseLogLevelConfig(%q) expected an error, got nil", s)
+		}
+	}
+}
+
+func TestPackageLoggerCapturesCallerAndStackLikePrint(t *testing.T) {
+	pl := NewPackageLogger("github.com/letsencrypt/boulder", "caller-test")
+	pl.SetLevel(LevelDebug)
+	defer pl.ResetLevel()
+
+	mb := NewMemoryBackend(3)
+	SetBackend(mb)
+	defer SetBackend(nil)
+
+	pl.Info("plain info message")
+	pl.Errorf("broken: %s", "pipe")
+	pl.Critical("on fire")
+
+	entries := mb.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	if strings.Contains(entries[0].Message, "package_logger_test.go:") {
+		t.Errorf("Info message should not have caller info appended: %q", entries[0].Message)
+	}
+	if !strings.Contains(entries[1].Message, "package_logger_test.go:") {
+		t.Errorf("Errorf message should have caller info appended: %q", entries[1].Message)
+	}
+	if !strings.Contains(entries[2].Message, "package_logger_test.go:") {
+		t.Errorf("Critical message should have caller info appended: %q", entries[2].Message)
+	}
+	if !strings.Contains(entries[2].Message, "goroutine ") {
+		t.Errorf("Critical message should have a stack trace appended: %q", entries[2].Message)
+	}
+}
@@ -0,0 +1,64 @@
+package log
+
+import "strings"
+
+// Redactor lets a type control how it appears in log output. Any
+// argument passed to Debug/Info/.../Fatal or their formatted
+// counterparts that implements Redactor has its Redacted() value
+// substituted in before the arguments reach fmt.Sprint/fmt.Sprintf, so
+// secret material never lands in a log line by accident.
+type Redactor interface {
+	Redacted() interface{}
+}
+
+// Redact returns a run of asterisks the same length as s, hiding a
+// secret's contents while still hinting at its length.
+func Redact(s string) string {
+	return strings.Repeat("*", len(s))
+}
+
+// redactArgs returns v with every element that implements Redactor
+// replaced by its Redacted() value. It returns v itself, unmodified,
+// when nothing needs redacting, to avoid an allocation on the common
+// path.
+func redactArgs(v []interface{}) []interface{} {
+	needsRedaction := false
+	for _, a := range v {
+		if _, ok := a.(Redactor); ok {
+			needsRedaction = true
+			break
+		}
+	}
+	if !needsRedaction {
+		return v
+	}
+	out := make([]interface{}, len(v))
+	for i, a := range v {
+		if r, ok := a.(Redactor); ok {
+			out[i] = r.Redacted()
+			continue
+		}
+		out[i] = a
+	}
+	return out
+}
+
+// AccountKey, JWSSignature, and APIToken mark the kinds of value that
+// most often end up logged by accident in a CA: an account's public
+// key material, a JWS signature, and a bearer API token. Wrap a value
+// in one of them (or any other Redactor) before passing it to
+// Debug/Info/.../Fatal, and the logged form will be redacted.
+type (
+	AccountKey   string
+	JWSSignature string
+	APIToken     string
+)
+
+// Redacted implements Redactor.
+func (k AccountKey) Redacted() interface{} { return Redact(string(k)) }
+
+// Redacted implements Redactor.
+func (s JWSSignature) Redacted() interface{} { return Redact(string(s)) }
+
+// Redacted implements Redactor.
+func (t APIToken) Redacted() interface{} { return Redact(string(t)) }
@@ -0,0 +1,53 @@
+package log
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	got := Redact("hunter2")
+	want := "*******"
+	if got != want {
+		t.Errorf("Redact(%q) = %q, want %q", "hunter2", got, want)
+	}
+}
+
+func TestSecretTypesImplementRedactor(t *testing.T) {
+	var _ Redactor = AccountKey("")
+	var _ Redactor = JWSSignature("")
+	var _ Redactor = APIToken("")
+}
+
+// TestLoggingNeverLeaksRedactedValues covers Debug/Info/Warning/Error/
+// Critical; Fatal is excluded because it calls os.Exit.
+func TestLoggingNeverLeaksRedactedValues(t *testing.T) {
+	const secretValue = "super-secret-key-material"
+	secret := AccountKey(secretValue)
+
+	savedLevel := Level
+	Level = LevelDebug
+	defer func() { Level = savedLevel }()
+
+	logFuncs := map[string]func(...interface{}){
+		"Debug":    Debug,
+		"Info":     Info,
+		"Warning":  Warning,
+		"Error":    Error,
+		"Critical": Critical,
+	}
+	for name, logFn := range logFuncs {
+		mb := NewMemoryBackend(1)
+		SetBackend(mb)
+		logFn(secret)
+		SetBackend(nil)
+
+		entries := mb.Entries()
+		if len(entries) != 1 {
+			t.Fatalf("%s: expected 1 entry, got %d", name, len(entries))
+		}
+		if strings.Contains(entries[0].Message, secretValue) {
+			t.Errorf("%s: secret leaked through: %q", name, entries[0].Message)
+		}
+	}
+}